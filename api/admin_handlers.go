@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/middleware"
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+// getAdminLogs - GET /admin/logs?page=&limit=&from=&to= (from/to are RFC3339).
+// Requires an admin-role bearer token.
+func (h *handler) getAdminLogs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if role, _ := middleware.RoleFromContext(r.Context()); role != "admin" {
+		http.Error(w, "admin role required", http.StatusForbidden)
+		return
+	}
+
+	var query model.LogQuery
+
+	if raw := r.URL.Query().Get("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page <= 0 {
+			http.Error(w, "invalid page", http.StatusBadRequest)
+			return
+		}
+		query.Page = page
+	}
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		query.Limit = limit
+	}
+
+	if raw := r.URL.Query().Get("from"); raw != "" {
+		from, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "invalid from, expected RFC3339", http.StatusBadRequest)
+			return
+		}
+		query.From = &from
+	}
+
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		to, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "invalid to, expected RFC3339", http.StatusBadRequest)
+			return
+		}
+		query.To = &to
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	page, err := h.logSvc.List(ctx, query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(page)
+}
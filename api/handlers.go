@@ -0,0 +1,250 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/middleware"
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// createLocation - POST /locations. Also accepts a whole FeatureCollection
+// for bulk insert.
+func (h *handler) createLocation(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	ownerID, _ := middleware.UserIDFromContext(r.Context())
+
+	if envelope.Type == "FeatureCollection" {
+		var fc model.FeatureCollection
+		if err := json.Unmarshal(body, &fc); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		created, err := h.svc.CreateBulk(ctx, fc.Features, ownerID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		json.NewEncoder(w).Encode(model.FeatureCollection{Type: "FeatureCollection", Features: created})
+		return
+	}
+
+	var feature model.LocationFeature
+	if err := json.Unmarshal(body, &feature); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.svc.Create(ctx, feature, ownerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(created)
+}
+
+// getLocations - GET /locations
+func (h *handler) getLocations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	role, _ := middleware.RoleFromContext(r.Context())
+
+	fc, err := h.svc.List(ctx, callerID, role == "admin")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(fc)
+}
+
+// getLocationsNear - GET /locations/near?lng=&lat=&maxMeters=&minMeters=&limit=
+func (h *handler) getLocationsNear(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	lng, lat, err := parseLngLat(r, "lng", "lat")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	maxMeters, err := strconv.ParseFloat(r.URL.Query().Get("maxMeters"), 64)
+	if err != nil {
+		http.Error(w, "invalid maxMeters", http.StatusBadRequest)
+		return
+	}
+
+	var minMeters *float64
+	if raw := r.URL.Query().Get("minMeters"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			http.Error(w, "invalid minMeters", http.StatusBadRequest)
+			return
+		}
+		minMeters = &v
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	role, _ := middleware.RoleFromContext(r.Context())
+
+	fc, err := h.svc.Near(ctx, lng, lat, maxMeters, minMeters, limit, callerID, role == "admin")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(fc)
+}
+
+// getLocationsBBox - GET /locations/bbox?minLng=&minLat=&maxLng=&maxLat=&limit=
+func (h *handler) getLocationsBBox(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	minLng, minLat, err := parseLngLat(r, "minLng", "minLat")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	maxLng, maxLat, err := parseLngLat(r, "maxLng", "maxLat")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	role, _ := middleware.RoleFromContext(r.Context())
+
+	fc, err := h.svc.WithinBBox(ctx, minLng, minLat, maxLng, maxLat, limit, callerID, role == "admin")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(fc)
+}
+
+// getLocationsWithinPolygon - POST /locations/within, body: GeoJSON Polygon
+func (h *handler) getLocationsWithinPolygon(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var geom model.Geometry
+	if err := json.NewDecoder(r.Body).Decode(&geom); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	role, _ := middleware.RoleFromContext(r.Context())
+
+	fc, err := h.svc.WithinPolygon(ctx, geom, limit, callerID, role == "admin")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(fc)
+}
+
+// updateLocation - PUT /locations/{id}
+func (h *handler) updateLocation(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var feature model.LocationFeature
+	if err := json.NewDecoder(r.Body).Decode(&feature); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	role, _ := middleware.RoleFromContext(r.Context())
+
+	updated, err := h.svc.Update(ctx, id, feature, callerID, role == "admin")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(updated)
+}
+
+// deleteLocation - DELETE /locations/{id}
+func (h *handler) deleteLocation(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	role, _ := middleware.RoleFromContext(r.Context())
+
+	if err := h.svc.Delete(ctx, id, callerID, role == "admin"); err != nil {
+		writeError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"message": "Location deleted successfully"})
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/GIS-GITA/UTS-BE/service"
+)
+
+const (
+	defaultQueryLimit = 100
+	maxQueryLimit     = 500
+)
+
+// parseLimit membaca query param "limit" dengan default dan batas atas yang aman.
+func parseLimit(r *http.Request) (int64, error) {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return defaultQueryLimit, nil
+	}
+	limit, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("invalid limit: %q", raw)
+	}
+	if limit > maxQueryLimit {
+		limit = maxQueryLimit
+	}
+	return limit, nil
+}
+
+// parseLngLat reads a pair of lng/lat query params; range validation is the
+// service layer's job, this just rejects unparsable input.
+func parseLngLat(r *http.Request, lngParam, latParam string) (lng, lat float64, err error) {
+	lng, err = strconv.ParseFloat(r.URL.Query().Get(lngParam), 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid %s", lngParam)
+	}
+	lat, err = strconv.ParseFloat(r.URL.Query().Get(latParam), 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid %s", latParam)
+	}
+	return lng, lat, nil
+}
+
+// writeError maps a service error to the right HTTP status code.
+func writeError(w http.ResponseWriter, err error) {
+	var verr *service.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrInvalidCredentials):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrEmailTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/importexport"
+	"github.com/GIS-GITA/UTS-BE/middleware"
+)
+
+// importResult is the summary returned by POST /locations/import.
+type importResult struct {
+	Inserted int      `json:"inserted"`
+	Skipped  int      `json:"skipped"`
+	Errors   []string `json:"errors"`
+}
+
+// importLocations - POST /locations/import, multipart/form-data with a
+// "format" field (geojson|kml|csv|shp), a "file" field, and optional CSV
+// column overrides (lng_col, lat_col, name_col, description_col).
+func (h *handler) importLocations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, fmt.Sprintf("invalid multipart form: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	format := r.FormValue("format")
+	if format == "" {
+		http.Error(w, "format is required", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	features, err := importexport.Decode(format, file, csvColumnsFromValues(r.FormValue))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	ownerID, _ := middleware.UserIDFromContext(r.Context())
+
+	result := importResult{Errors: []string{}}
+	for i, feature := range features {
+		if _, err := h.svc.Create(ctx, feature, ownerID); err != nil {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("feature %d: %v", i, err))
+			continue
+		}
+		result.Inserted++
+	}
+
+	json.NewEncoder(w).Encode(result)
+}
+
+// exportLocations - GET /locations/export?format=geojson|kml|csv, with
+// optional CSV column overrides (lng_col, lat_col, name_col, description_col).
+func (h *handler) exportLocations(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = importexport.FormatGeoJSON
+	}
+
+	contentType, ok := importexport.ContentType(format)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unsupported export format: %q", format), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	role, _ := middleware.RoleFromContext(r.Context())
+
+	collection, err := h.svc.List(ctx, callerID, role == "admin")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=locations.%s", importexport.FileExtension(format)))
+
+	columns := csvColumnsFromValues(r.URL.Query().Get)
+	if err := importexport.Encode(format, w, collection.Features, columns); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// csvColumnsFromValues reads CSV column overrides through a getter so the
+// same logic works for multipart form values and URL query params.
+func csvColumnsFromValues(get func(string) string) importexport.CSVColumns {
+	columns := importexport.DefaultCSVColumns()
+	if v := get("lng_col"); v != "" {
+		columns.Lng = v
+	}
+	if v := get("lat_col"); v != "" {
+		columns.Lat = v
+	}
+	if v := get("name_col"); v != "" {
+		columns.Name = v
+	}
+	if v := get("description_col"); v != "" {
+		columns.Description = v
+	}
+	return columns
+}
@@ -0,0 +1,112 @@
+// Package api contains the HTTP handlers. They call the services only
+// through the interfaces declared below, never through the concrete service
+// types, so they stay mockable for unit tests; package service is imported
+// only for the error values writeError maps to HTTP status codes.
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/GIS-GITA/UTS-BE/middleware"
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type locationService interface {
+	Create(ctx context.Context, feature model.LocationFeature, ownerID primitive.ObjectID) (model.LocationFeature, error)
+	CreateBulk(ctx context.Context, features []model.LocationFeature, ownerID primitive.ObjectID) ([]model.LocationFeature, error)
+	List(ctx context.Context, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error)
+	Near(ctx context.Context, lng, lat, maxMeters float64, minMeters *float64, limit int64, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error)
+	WithinBBox(ctx context.Context, minLng, minLat, maxLng, maxLat float64, limit int64, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error)
+	WithinPolygon(ctx context.Context, geom model.Geometry, limit int64, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error)
+	Update(ctx context.Context, id primitive.ObjectID, feature model.LocationFeature, callerID primitive.ObjectID, isAdmin bool) (model.LocationFeature, error)
+	Delete(ctx context.Context, id primitive.ObjectID, callerID primitive.ObjectID, isAdmin bool) error
+}
+
+// requestLogService embeds middleware.RequestLogWriter so a single
+// service.RequestLogService can both feed the logging middleware and back
+// the /admin/logs handler.
+type requestLogService interface {
+	middleware.RequestLogWriter
+	List(ctx context.Context, query model.LogQuery) (model.RequestLogPage, error)
+}
+
+// geocodeService backs the /geocode and /reverse passthrough endpoints.
+type geocodeService interface {
+	Forward(ctx context.Context, query string) (model.GeocodeResult, error)
+	Reverse(ctx context.Context, lng, lat float64) (model.GeocodeResult, error)
+}
+
+// authService backs /auth/register and /auth/login.
+type authService interface {
+	Register(ctx context.Context, email, password string) (model.User, error)
+	Login(ctx context.Context, email, password string) (string, error)
+}
+
+type handler struct {
+	svc     locationService
+	logSvc  requestLogService
+	geoSvc  geocodeService
+	authSvc authService
+}
+
+// NewRouter builds the mux.Router shared by the standalone server (main.go)
+// and the Vercel Handler entrypoint. jwtSecret is used by
+// middleware.AuthMiddleware, which the mutating /locations routes and
+// /admin/logs require, and which runs on every other route whenever the
+// request carries an Authorization header.
+func NewRouter(svc locationService, logSvc requestLogService, geoSvc geocodeService, authSvc authService, jwtSecret string) *mux.Router {
+	h := &handler{svc: svc, logSvc: logSvc, geoSvc: geoSvc, authSvc: authSvc}
+
+	r := mux.NewRouter()
+	r.Use(corsMiddleware)
+	// No IPLocator is wired in yet - IP-based geolocation fallback needs a
+	// provider this project doesn't depend on, so X-Geo is the only source
+	// for now (see middleware.IPLocator).
+	r.Use(middleware.RequestLogger(logSvc, nil))
+
+	// Read routes stay open to anonymous callers, but a request that sends
+	// an Authorization header is authenticated (401 if the token is invalid),
+	// so the caller's own private features and the admin role are honoured.
+	authenticate := middleware.AuthMiddleware(jwtSecret)
+	r.Use(func(next http.Handler) http.Handler {
+		authed := authenticate(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+			authed.ServeHTTP(w, req)
+		})
+	})
+
+	r.HandleFunc("/auth/register", h.register).Methods("POST", "OPTIONS")
+	r.HandleFunc("/auth/login", h.login).Methods("POST", "OPTIONS")
+
+	r.HandleFunc("/locations", h.getLocations).Methods("GET", "OPTIONS")
+	r.HandleFunc("/locations/near", h.getLocationsNear).Methods("GET", "OPTIONS")
+	r.HandleFunc("/locations/bbox", h.getLocationsBBox).Methods("GET", "OPTIONS")
+	r.HandleFunc("/locations/within", h.getLocationsWithinPolygon).Methods("POST", "OPTIONS")
+	r.HandleFunc("/locations/export", h.exportLocations).Methods("GET", "OPTIONS")
+	r.HandleFunc("/geocode", h.getGeocode).Methods("GET", "OPTIONS")
+	r.HandleFunc("/reverse", h.getReverseGeocode).Methods("GET", "OPTIONS")
+
+	// Mutating routes require a valid bearer token; the caller's id and
+	// role are injected into the request context for ownership checks.
+	mutating := r.NewRoute().Subrouter()
+	mutating.Use(middleware.AuthMiddleware(jwtSecret))
+	mutating.HandleFunc("/locations", h.createLocation).Methods("POST", "OPTIONS")
+	mutating.HandleFunc("/locations/import", h.importLocations).Methods("POST", "OPTIONS")
+	mutating.HandleFunc("/locations/{id}", h.updateLocation).Methods("PUT", "OPTIONS")
+	mutating.HandleFunc("/locations/{id}", h.deleteLocation).Methods("DELETE", "OPTIONS")
+
+	// /admin/logs requires a valid bearer token belonging to an admin;
+	// getAdminLogs itself checks the role injected by AuthMiddleware.
+	admin := r.NewRoute().Subrouter()
+	admin.Use(middleware.AuthMiddleware(jwtSecret))
+	admin.HandleFunc("/admin/logs", h.getAdminLogs).Methods("GET", "OPTIONS")
+
+	return r
+}
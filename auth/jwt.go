@@ -0,0 +1,46 @@
+// Package auth issues and verifies the HS256 JWTs used to authenticate API
+// callers. It has no dependency on service or repository so that
+// middleware.AuthMiddleware can verify tokens without importing the
+// service layer.
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Claims is the payload embedded in every token this package issues.
+type Claims struct {
+	UserID primitive.ObjectID `json:"uid"`
+	Role   string             `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// NewToken signs a token carrying userID and role, valid for ttl.
+func NewToken(secret string, userID primitive.ObjectID, role string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID: userID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ParseToken verifies tokenString against secret and returns its claims.
+func ParseToken(secret, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
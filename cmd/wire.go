@@ -0,0 +1,84 @@
+// Package cmd wires the repository and service layers together so both
+// main.go and the Vercel api.Handler entrypoint bootstrap from the same
+// place instead of duplicating the Mongo connection logic.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/geocoder"
+	"github.com/GIS-GITA/UTS-BE/repository"
+	"github.com/GIS-GITA/UTS-BE/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// geocodeCacheTTLSeconds is how long a cached geocode lookup stays valid
+// before the TTL index reaps it.
+const geocodeCacheTTLSeconds = 30 * 24 * 60 * 60
+
+// NewApp connects to MongoDB at mongoURI, makes sure the indexes the API
+// relies on exist, and returns the services and JWT secret ready to be
+// passed to api.NewRouter.
+func NewApp(mongoURI string) (*service.LocationService, *service.RequestLogService, *service.GeocodeService, *service.AuthService, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		return nil, nil, nil, nil, "", err
+	}
+
+	db := client.Database("gis_db")
+
+	userCollection := db.Collection("users")
+	userIndex := mongo.IndexModel{Keys: bson.M{"email": 1}, Options: options.Index().SetUnique(true)}
+	if _, err := userCollection.Indexes().CreateOne(ctx, userIndex); err != nil {
+		log.Println("Could not create unique index on users.email, it might already exist.")
+	}
+
+	locationCollection := db.Collection("locations")
+	locationIndex := mongo.IndexModel{Keys: bson.M{"geometry": "2dsphere"}}
+	if _, err := locationCollection.Indexes().CreateOne(ctx, locationIndex); err != nil {
+		log.Println("Could not create 2dsphere index on locations, it might already exist.")
+	}
+
+	logCollection := db.Collection("request_logs")
+	logIndex := mongo.IndexModel{Keys: bson.M{"geo": "2dsphere"}}
+	if _, err := logCollection.Indexes().CreateOne(ctx, logIndex); err != nil {
+		log.Println("Could not create 2dsphere index on request_logs, it might already exist.")
+	}
+
+	geocodeCacheCollection := db.Collection("geocode_cache")
+	geocodeCacheIndex := mongo.IndexModel{
+		Keys:    bson.M{"created_at": 1},
+		Options: options.Index().SetExpireAfterSeconds(geocodeCacheTTLSeconds),
+	}
+	if _, err := geocodeCacheCollection.Indexes().CreateOne(ctx, geocodeCacheIndex); err != nil {
+		log.Println("Could not create TTL index on geocode_cache, it might already exist.")
+	}
+
+	userRepo := repository.NewMongoUserRepository(userCollection)
+	locationRepo := repository.NewMongoLocationRepository(locationCollection)
+	logRepo := repository.NewMongoRequestLogRepository(logCollection)
+	geocodeCacheRepo := repository.NewMongoGeocodeCacheRepository(geocodeCacheCollection)
+
+	provider := geocoder.New(os.Getenv("GEOCODER_PROVIDER"), os.Getenv("GEOCODER_API_KEY"))
+	geocodeSvc := service.NewGeocodeService(provider, geocodeCacheRepo)
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		return nil, nil, nil, nil, "", errors.New("JWT_SECRET environment variable is not set")
+	}
+
+	locationSvc := service.NewLocationService(locationRepo, geocodeSvc)
+	logSvc := service.NewRequestLogService(logRepo)
+	authSvc := service.NewAuthService(userRepo, jwtSecret)
+
+	return locationSvc, logSvc, geocodeSvc, authSvc, jwtSecret, nil
+}
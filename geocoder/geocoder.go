@@ -0,0 +1,26 @@
+// Package geocoder provides pluggable forward/reverse geocoding backends.
+package geocoder
+
+import (
+	"context"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+// Geocoder turns a free-text address into coordinates (Forward) or
+// coordinates into an address (Reverse).
+type Geocoder interface {
+	Forward(ctx context.Context, query string) (model.GeocodeResult, error)
+	Reverse(ctx context.Context, lng, lat float64) (model.GeocodeResult, error)
+}
+
+// New selects a Geocoder implementation based on provider. Unknown or empty
+// providers fall back to Nominatim, which needs no API key.
+func New(provider, apiKey string) Geocoder {
+	switch provider {
+	case "google":
+		return NewGoogleGeocoder(apiKey)
+	default:
+		return NewNominatimGeocoder()
+	}
+}
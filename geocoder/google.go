@@ -0,0 +1,94 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+const googleGeocodeBaseURL = "https://maps.googleapis.com/maps/api/geocode/json"
+
+// GoogleGeocoder calls the Google Geocoding API. Selected via
+// GEOCODER_PROVIDER=google and authenticated with GEOCODER_API_KEY.
+type GoogleGeocoder struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewGoogleGeocoder builds a GoogleGeocoder using the given API key.
+func NewGoogleGeocoder(apiKey string) *GoogleGeocoder {
+	return &GoogleGeocoder{apiKey: apiKey, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type googleGeocodeResponse struct {
+	Status  string `json:"status"`
+	Results []struct {
+		FormattedAddress string `json:"formatted_address"`
+		Geometry         struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"geometry"`
+		AddressComponents []struct {
+			LongName string   `json:"long_name"`
+			Types    []string `json:"types"`
+		} `json:"address_components"`
+	} `json:"results"`
+}
+
+func (g *GoogleGeocoder) Forward(ctx context.Context, query string) (model.GeocodeResult, error) {
+	u := fmt.Sprintf("%s?address=%s&key=%s", googleGeocodeBaseURL, url.QueryEscape(query), url.QueryEscape(g.apiKey))
+	return g.do(ctx, u)
+}
+
+func (g *GoogleGeocoder) Reverse(ctx context.Context, lng, lat float64) (model.GeocodeResult, error) {
+	u := fmt.Sprintf("%s?latlng=%f,%f&key=%s", googleGeocodeBaseURL, lat, lng, url.QueryEscape(g.apiKey))
+	return g.do(ctx, u)
+}
+
+func (g *GoogleGeocoder) do(ctx context.Context, u string) (model.GeocodeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return model.GeocodeResult{}, err
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return model.GeocodeResult{}, err
+	}
+	defer resp.Body.Close()
+
+	var parsed googleGeocodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return model.GeocodeResult{}, err
+	}
+	if parsed.Status != "OK" || len(parsed.Results) == 0 {
+		return model.GeocodeResult{}, fmt.Errorf("google geocode request failed: %s", parsed.Status)
+	}
+
+	result := parsed.Results[0]
+	geocoded := model.GeocodeResult{
+		Lng:     result.Geometry.Location.Lng,
+		Lat:     result.Geometry.Location.Lat,
+		Address: result.FormattedAddress,
+	}
+	for _, comp := range result.AddressComponents {
+		for _, t := range comp.Types {
+			switch t {
+			case "locality":
+				geocoded.City = comp.LongName
+			case "country":
+				geocoded.Country = comp.LongName
+			case "postal_code":
+				geocoded.Postcode = comp.LongName
+			}
+		}
+	}
+	return geocoded, nil
+}
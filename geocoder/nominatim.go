@@ -0,0 +1,112 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+const nominatimBaseURL = "https://nominatim.openstreetmap.org"
+
+// NominatimGeocoder calls the public OpenStreetMap Nominatim API. No API
+// key is required, but callers must respect Nominatim's usage policy
+// (this is why results are cached in geocode_cache).
+type NominatimGeocoder struct {
+	httpClient *http.Client
+}
+
+// NewNominatimGeocoder builds a NominatimGeocoder with a sane timeout.
+func NewNominatimGeocoder() *NominatimGeocoder {
+	return &NominatimGeocoder{httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type nominatimAddress struct {
+	City     string `json:"city"`
+	Town     string `json:"town"`
+	Village  string `json:"village"`
+	Country  string `json:"country"`
+	Postcode string `json:"postcode"`
+}
+
+type nominatimResult struct {
+	Lat         string           `json:"lat"`
+	Lon         string           `json:"lon"`
+	DisplayName string           `json:"display_name"`
+	Address     nominatimAddress `json:"address"`
+}
+
+func (g *NominatimGeocoder) Forward(ctx context.Context, query string) (model.GeocodeResult, error) {
+	u := fmt.Sprintf("%s/search?q=%s&format=jsonv2&addressdetails=1&limit=1", nominatimBaseURL, url.QueryEscape(query))
+
+	var results []nominatimResult
+	if err := g.get(ctx, u, &results); err != nil {
+		return model.GeocodeResult{}, err
+	}
+	if len(results) == 0 {
+		return model.GeocodeResult{}, fmt.Errorf("no geocode results for %q", query)
+	}
+	return toGeocodeResult(results[0])
+}
+
+func (g *NominatimGeocoder) Reverse(ctx context.Context, lng, lat float64) (model.GeocodeResult, error) {
+	u := fmt.Sprintf("%s/reverse?lat=%f&lon=%f&format=jsonv2&addressdetails=1", nominatimBaseURL, lat, lng)
+
+	var result nominatimResult
+	if err := g.get(ctx, u, &result); err != nil {
+		return model.GeocodeResult{}, err
+	}
+	return toGeocodeResult(result)
+}
+
+func (g *NominatimGeocoder) get(ctx context.Context, u string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "UTS-BE-GIS-service/1.0")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("nominatim request failed: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func toGeocodeResult(r nominatimResult) (model.GeocodeResult, error) {
+	lat, err := strconv.ParseFloat(r.Lat, 64)
+	if err != nil {
+		return model.GeocodeResult{}, fmt.Errorf("invalid lat in nominatim response: %w", err)
+	}
+	lon, err := strconv.ParseFloat(r.Lon, 64)
+	if err != nil {
+		return model.GeocodeResult{}, fmt.Errorf("invalid lon in nominatim response: %w", err)
+	}
+
+	city := r.Address.City
+	if city == "" {
+		city = r.Address.Town
+	}
+	if city == "" {
+		city = r.Address.Village
+	}
+
+	return model.GeocodeResult{
+		Lng:      lon,
+		Lat:      lat,
+		Address:  r.DisplayName,
+		City:     city,
+		Country:  r.Address.Country,
+		Postcode: r.Address.Postcode,
+	}, nil
+}
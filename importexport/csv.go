@@ -0,0 +1,92 @@
+package importexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+func decodeCSV(r io.Reader, columns CSVColumns) ([]model.LocationFeature, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV has no rows")
+	}
+
+	col := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		col[name] = i
+	}
+
+	lngIdx, ok := col[columns.Lng]
+	if !ok {
+		return nil, fmt.Errorf("CSV missing %q column", columns.Lng)
+	}
+	latIdx, ok := col[columns.Lat]
+	if !ok {
+		return nil, fmt.Errorf("CSV missing %q column", columns.Lat)
+	}
+	nameIdx, hasName := col[columns.Name]
+	descIdx, hasDesc := col[columns.Description]
+
+	features := make([]model.LocationFeature, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		lng, err := strconv.ParseFloat(row[lngIdx], 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid %s %q", i+1, columns.Lng, row[lngIdx])
+		}
+		lat, err := strconv.ParseFloat(row[latIdx], 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid %s %q", i+1, columns.Lat, row[latIdx])
+		}
+
+		props := model.Properties{}
+		if hasName {
+			props.Name = row[nameIdx]
+		}
+		if hasDesc {
+			props.Description = row[descIdx]
+		}
+
+		features = append(features, model.LocationFeature{
+			Type:       "Feature",
+			Properties: props,
+			Geometry:   model.Geometry{Type: "Point", Coordinates: []float64{lng, lat}},
+		})
+	}
+	return features, nil
+}
+
+func encodeCSV(w io.Writer, features []model.LocationFeature, columns CSVColumns) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{columns.Lng, columns.Lat, columns.Name, columns.Description}); err != nil {
+		return err
+	}
+
+	for _, feature := range features {
+		pos, err := toPoint(feature.Geometry)
+		if err != nil {
+			continue
+		}
+		row := []string{
+			strconv.FormatFloat(pos[0], 'f', -1, 64),
+			strconv.FormatFloat(pos[1], 'f', -1, 64),
+			feature.Properties.Name,
+			feature.Properties.Description,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return writer.Error()
+}
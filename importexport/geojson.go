@@ -0,0 +1,96 @@
+package importexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/paulmach/orb/geojson"
+)
+
+func decodeGeoJSON(r io.Reader) ([]model.LocationFeature, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fc, err := geojson.UnmarshalFeatureCollection(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON FeatureCollection: %w", err)
+	}
+
+	features := make([]model.LocationFeature, 0, len(fc.Features))
+	for _, f := range fc.Features {
+		geom, err := orbToModelGeometry(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, model.LocationFeature{
+			Type:       "Feature",
+			Geometry:   geom,
+			Properties: propertiesFromMap(f.Properties),
+		})
+	}
+	return features, nil
+}
+
+func encodeGeoJSON(w io.Writer, features []model.LocationFeature) error {
+	fc := geojson.NewFeatureCollection()
+	for _, feature := range features {
+		orbGeom, err := modelToOrbGeometry(feature.Geometry)
+		if err != nil {
+			return err
+		}
+		f := geojson.NewFeature(orbGeom)
+		f.Properties = propertiesToMap(feature.Properties)
+		if !feature.ID.IsZero() {
+			f.ID = feature.ID.Hex()
+		}
+		fc.Append(f)
+	}
+	return json.NewEncoder(w).Encode(fc)
+}
+
+func propertiesFromMap(props geojson.Properties) model.Properties {
+	p := model.Properties{}
+	if v, ok := props["name"].(string); ok {
+		p.Name = v
+	}
+	if v, ok := props["description"].(string); ok {
+		p.Description = v
+	}
+	if v, ok := props["address"].(string); ok {
+		p.Address = v
+	}
+	if v, ok := props["city"].(string); ok {
+		p.City = v
+	}
+	if v, ok := props["country"].(string); ok {
+		p.Country = v
+	}
+	if v, ok := props["postcode"].(string); ok {
+		p.Postcode = v
+	}
+	return p
+}
+
+func propertiesToMap(p model.Properties) geojson.Properties {
+	props := geojson.Properties{
+		"name":        p.Name,
+		"description": p.Description,
+	}
+	if p.Address != "" {
+		props["address"] = p.Address
+	}
+	if p.City != "" {
+		props["city"] = p.City
+	}
+	if p.Country != "" {
+		props["country"] = p.Country
+	}
+	if p.Postcode != "" {
+		props["postcode"] = p.Postcode
+	}
+	return props
+}
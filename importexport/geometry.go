@@ -0,0 +1,74 @@
+package importexport
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// orbToModelGeometry round-trips an orb.Geometry through GeoJSON to get it
+// into model.Geometry's {type, coordinates} shape.
+func orbToModelGeometry(g orb.Geometry) (model.Geometry, error) {
+	raw, err := geojson.NewGeometry(g).MarshalJSON()
+	if err != nil {
+		return model.Geometry{}, err
+	}
+	var out model.Geometry
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return model.Geometry{}, err
+	}
+	return out, nil
+}
+
+// modelToOrbGeometry is the inverse of orbToModelGeometry.
+func modelToOrbGeometry(g model.Geometry) (orb.Geometry, error) {
+	raw, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := geojson.UnmarshalGeometry(raw)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Geometry(), nil
+}
+
+// toPoint extracts [lng, lat] from a Point geometry. Coordinates may be
+// []float64 (set directly by our own code), []interface{} (decoded from
+// JSON), or primitive.A (decoded by the Mongo driver when a feature is read
+// back out of the database), so all three shapes are handled.
+func toPoint(g model.Geometry) ([2]float64, error) {
+	if g.Type != "Point" {
+		return [2]float64{}, fmt.Errorf("expected Point geometry, got %q", g.Type)
+	}
+
+	switch coords := g.Coordinates.(type) {
+	case []float64:
+		if len(coords) < 2 {
+			return [2]float64{}, fmt.Errorf("invalid Point coordinates")
+		}
+		return [2]float64{coords[0], coords[1]}, nil
+	case []interface{}:
+		return pointFromSlice(coords)
+	case primitive.A:
+		return pointFromSlice(coords)
+	default:
+		return [2]float64{}, fmt.Errorf("invalid Point coordinates")
+	}
+}
+
+func pointFromSlice(coords []interface{}) ([2]float64, error) {
+	if len(coords) < 2 {
+		return [2]float64{}, fmt.Errorf("invalid Point coordinates")
+	}
+	lng, ok1 := coords[0].(float64)
+	lat, ok2 := coords[1].(float64)
+	if !ok1 || !ok2 {
+		return [2]float64{}, fmt.Errorf("invalid Point coordinates")
+	}
+	return [2]float64{lng, lat}, nil
+}
@@ -0,0 +1,97 @@
+// Package importexport converts between model.LocationFeature and the
+// on-the-wire GIS formats /locations/import and /locations/export accept:
+// GeoJSON, KML, CSV, and (import-only) zipped Shapefiles.
+package importexport
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+// Supported format identifiers, as passed in the "format" form field or
+// export query param.
+const (
+	FormatGeoJSON = "geojson"
+	FormatKML     = "kml"
+	FormatCSV     = "csv"
+	FormatSHP     = "shp"
+)
+
+// CSVColumns maps CSV headers to feature fields. Callers can override any of
+// these via query/form params so the endpoint works with CSVs that don't use
+// the default header names.
+type CSVColumns struct {
+	Lng         string
+	Lat         string
+	Name        string
+	Description string
+}
+
+// DefaultCSVColumns is used when the caller doesn't override the mapping.
+func DefaultCSVColumns() CSVColumns {
+	return CSVColumns{Lng: "lng", Lat: "lat", Name: "name", Description: "description"}
+}
+
+// Decode parses r according to format into features ready for
+// LocationService.Create. columns only applies to format == FormatCSV.
+func Decode(format string, r io.Reader, columns CSVColumns) ([]model.LocationFeature, error) {
+	switch format {
+	case FormatGeoJSON:
+		return decodeGeoJSON(r)
+	case FormatKML:
+		return decodeKML(r)
+	case FormatCSV:
+		return decodeCSV(r, columns)
+	case FormatSHP:
+		return decodeShapefile(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %q", format)
+	}
+}
+
+// Encode writes features to w according to format. columns only applies to
+// format == FormatCSV. Shapefile export isn't supported - round-tripping a
+// .shp/.shx/.dbf/.prj set isn't worth it when GeoJSON/KML/CSV cover the same
+// data.
+func Encode(format string, w io.Writer, features []model.LocationFeature, columns CSVColumns) error {
+	switch format {
+	case FormatGeoJSON:
+		return encodeGeoJSON(w, features)
+	case FormatKML:
+		return encodeKML(w, features)
+	case FormatCSV:
+		return encodeCSV(w, features, columns)
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+// ContentType returns the MIME type to use for an export response, and false
+// if format isn't exportable.
+func ContentType(format string) (string, bool) {
+	switch format {
+	case FormatGeoJSON:
+		return "application/geo+json", true
+	case FormatKML:
+		return "application/vnd.google-earth.kml+xml", true
+	case FormatCSV:
+		return "text/csv", true
+	default:
+		return "", false
+	}
+}
+
+// FileExtension returns the filename extension for an export's
+// Content-Disposition header.
+func FileExtension(format string) string {
+	switch format {
+	case FormatKML:
+		return "kml"
+	case FormatCSV:
+		return "csv"
+	default:
+		return "geojson"
+	}
+}
@@ -0,0 +1,104 @@
+package importexport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+type kmlDocument struct {
+	XMLName  xml.Name        `xml:"kml"`
+	Document kmlDocumentBody `xml:"Document"`
+}
+
+type kmlDocumentBody struct {
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string    `xml:"name"`
+	Description string    `xml:"description"`
+	Point       *kmlPoint `xml:"Point"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// decodeKML reads Point placemarks out of a KML document. Lines and
+// polygons aren't supported - a "location" in this API is a single pin.
+func decodeKML(r io.Reader) ([]model.LocationFeature, error) {
+	var doc kmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid KML: %w", err)
+	}
+
+	features := make([]model.LocationFeature, 0, len(doc.Document.Placemarks))
+	for _, pm := range doc.Document.Placemarks {
+		if pm.Point == nil {
+			continue
+		}
+		lng, lat, err := parseKMLCoordinates(pm.Point.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, model.LocationFeature{
+			Type:       "Feature",
+			Properties: model.Properties{Name: pm.Name, Description: pm.Description},
+			Geometry:   model.Geometry{Type: "Point", Coordinates: []float64{lng, lat}},
+		})
+	}
+	return features, nil
+}
+
+func parseKMLCoordinates(raw string) (lng, lat float64, err error) {
+	parts := strings.Split(strings.TrimSpace(raw), ",")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid KML coordinates: %q", raw)
+	}
+	lng, err = strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid KML longitude: %q", parts[0])
+	}
+	lat, err = strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid KML latitude: %q", parts[1])
+	}
+	return lng, lat, nil
+}
+
+// encodeKML writes features as Point placemarks; non-Point geometries are
+// skipped, mirroring decodeKML's scope.
+func encodeKML(w io.Writer, features []model.LocationFeature) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `<kml xmlns="http://www.opengis.net/kml/2.2"><Document>`); err != nil {
+		return err
+	}
+
+	for _, feature := range features {
+		pos, err := toPoint(feature.Geometry)
+		if err != nil {
+			continue
+		}
+		_, err = fmt.Fprintf(w, "<Placemark><name>%s</name><description>%s</description><Point><coordinates>%f,%f,0</coordinates></Point></Placemark>",
+			xmlEscape(feature.Properties.Name), xmlEscape(feature.Properties.Description), pos[0], pos[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, `</Document></kml>`)
+	return err
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
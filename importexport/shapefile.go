@@ -0,0 +1,185 @@
+package importexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+const shpPointType = 1
+
+// decodeShapefile reads Point features out of a zip archive containing the
+// .shp/.shx/.dbf/.prj sibling files (the .shx index and .prj projection
+// aren't needed for a flat Point read and are ignored). Only the Point
+// shape type is supported, since this endpoint is for importing locations,
+// not arbitrary GIS layers.
+func decodeShapefile(r io.Reader) ([]model.LocationFeature, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid shapefile zip: %w", err)
+	}
+
+	var shpBytes, dbfBytes []byte
+	for _, f := range zr.File {
+		switch strings.ToLower(filepath.Ext(f.Name)) {
+		case ".shp":
+			if shpBytes, err = readZipFile(f); err != nil {
+				return nil, err
+			}
+		case ".dbf":
+			if dbfBytes, err = readZipFile(f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if shpBytes == nil {
+		return nil, fmt.Errorf("zip has no .shp file")
+	}
+
+	points, err := parseShpPoints(shpBytes)
+	if err != nil {
+		return nil, err
+	}
+	attrs := parseDBFRows(dbfBytes)
+
+	features := make([]model.LocationFeature, 0, len(points))
+	for i, pos := range points {
+		props := model.Properties{}
+		if i < len(attrs) {
+			props = attrs[i]
+		}
+		features = append(features, model.LocationFeature{
+			Type:       "Feature",
+			Properties: props,
+			Geometry:   model.Geometry{Type: "Point", Coordinates: []float64{pos[0], pos[1]}},
+		})
+	}
+	return features, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// parseShpPoints walks the .shp record stream. Per the ESRI Shapefile spec,
+// the file starts with a 100-byte header, then each record is an 8-byte
+// big-endian header (record number, content length in 16-bit words)
+// followed by little-endian shape content.
+func parseShpPoints(data []byte) ([][2]float64, error) {
+	if len(data) < 100 {
+		return nil, fmt.Errorf("shp file too short")
+	}
+
+	var points [][2]float64
+	offset := 100
+	for offset+8 <= len(data) {
+		contentLenWords := binary.BigEndian.Uint32(data[offset+4 : offset+8])
+		contentStart := offset + 8
+		contentLen := int(contentLenWords) * 2
+		if contentLen < 4 || contentStart+contentLen > len(data) {
+			break
+		}
+
+		shapeType := binary.LittleEndian.Uint32(data[contentStart : contentStart+4])
+		if shapeType != shpPointType {
+			return nil, fmt.Errorf("unsupported shape type %d: only Point shapefiles are supported", shapeType)
+		}
+		if contentStart+20 > len(data) {
+			return nil, fmt.Errorf("truncated Point record")
+		}
+
+		x := math.Float64frombits(binary.LittleEndian.Uint64(data[contentStart+4 : contentStart+12]))
+		y := math.Float64frombits(binary.LittleEndian.Uint64(data[contentStart+12 : contentStart+20]))
+		points = append(points, [2]float64{x, y})
+
+		offset = contentStart + contentLen
+	}
+	return points, nil
+}
+
+type dbfField struct {
+	offset int
+	length int
+	char   bool
+}
+
+// parseDBFRows does a best-effort read of a DBF's fields, mapping the first
+// two character fields it finds to Name and Description - this mirrors how
+// most shapefile exports from QGIS/ArcGIS name their attributes, without
+// requiring the caller to know the exact schema.
+func parseDBFRows(data []byte) []model.Properties {
+	if len(data) < 32 {
+		return nil
+	}
+
+	numRecords := int(binary.LittleEndian.Uint32(data[4:8]))
+	headerLen := int(binary.LittleEndian.Uint16(data[8:10]))
+	recordLen := int(binary.LittleEndian.Uint16(data[10:12]))
+
+	var fields []dbfField
+	fieldOffset := 1 // byte 0 of every record is the deletion flag
+	for pos := 32; pos+32 <= len(data) && pos+1 < headerLen && data[pos] != 0x0D; pos += 32 {
+		length := int(data[pos+16])
+		fields = append(fields, dbfField{offset: fieldOffset, length: length, char: data[pos+11] == 'C'})
+		fieldOffset += length
+	}
+
+	// numRecords comes straight from the file header, so a crafted .dbf can
+	// claim far more records than the buffer could actually hold - cap the
+	// preallocation at what recordLen-sized rows could fit in the remaining
+	// bytes instead of trusting it outright. A non-positive recordLen can't
+	// be used to bound anything, so treat it as zero rows.
+	if recordLen <= 0 || headerLen >= len(data) {
+		numRecords = 0
+	} else if maxRecords := (len(data) - headerLen) / recordLen; maxRecords < numRecords {
+		numRecords = maxRecords
+	}
+
+	results := make([]model.Properties, 0, numRecords)
+	for i := 0; i < numRecords; i++ {
+		start := headerLen + i*recordLen
+		if start+recordLen > len(data) {
+			break
+		}
+		record := data[start : start+recordLen]
+
+		props := model.Properties{}
+		charIdx := 0
+		for _, f := range fields {
+			if !f.char {
+				continue
+			}
+			idx := charIdx
+			charIdx++
+			if f.offset+f.length > len(record) {
+				continue
+			}
+			value := strings.TrimSpace(string(record[f.offset : f.offset+f.length]))
+			switch idx {
+			case 0:
+				props.Name = value
+			case 1:
+				props.Description = value
+			}
+		}
+		results = append(results, props)
+	}
+	return results
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/GIS-GITA/UTS-BE/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type contextKey int
+
+const (
+	userIDContextKey contextKey = iota
+	roleContextKey
+)
+
+// UserIDFromContext returns the authenticated caller's user id, if any.
+func UserIDFromContext(ctx context.Context) (primitive.ObjectID, bool) {
+	id, ok := ctx.Value(userIDContextKey).(primitive.ObjectID)
+	return id, ok
+}
+
+// RoleFromContext returns the authenticated caller's role, if any.
+func RoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(roleContextKey).(string)
+	return role, ok
+}
+
+// AuthMiddleware requires a valid "Authorization: Bearer <token>" header
+// signed with secret, rejecting the request with 401 otherwise, and
+// injects the caller's user id and role into the request context for
+// downstream handlers (and ownership checks in service.LocationService).
+func AuthMiddleware(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, "Bearer ") {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := auth.ParseToken(secret, strings.TrimPrefix(header, "Bearer "))
+			if err != nil {
+				http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDContextKey, claims.UserID)
+			ctx = context.WithValue(ctx, roleContextKey, claims.Role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
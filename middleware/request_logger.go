@@ -0,0 +1,130 @@
+// Package middleware holds HTTP middleware shared across entrypoints.
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RequestLogWriter is the minimal surface RequestLogger needs to persist a log entry.
+type RequestLogWriter interface {
+	Record(ctx context.Context, log model.RequestLog) error
+}
+
+// IPLocator resolves a client IP to an approximate location, the pluggable
+// extension point for IP-based geolocation - mirrors geocoder.Geocoder on
+// the forward/reverse geocoding side. A nil IPLocator disables the
+// fallback, so X-Geo stays the only source until a provider is wired in.
+type IPLocator interface {
+	Locate(ctx context.Context, ip string) (*model.Geometry, error)
+}
+
+// responseRecorder captures the status code and byte count written downstream.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += n
+	return n, err
+}
+
+// RequestLogger writes one document per request into writer, echoing the
+// generated trace id back as X-Trace-Id. The write happens in the
+// background so it never adds latency to the response. locator may be nil
+// to disable the IP-based geolocation fallback.
+func RequestLogger(writer RequestLogWriter, locator IPLocator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceID := primitive.NewObjectID().Hex()
+			w.Header().Set("X-Trace-Id", traceID)
+
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			ip := remoteIP(r)
+			entry := model.RequestLog{
+				TraceID:   traceID,
+				Timestamp: start,
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Query:     r.URL.RawQuery,
+				RemoteIP:  ip,
+				UserAgent: r.UserAgent(),
+				Status:    rec.status,
+				Bytes:     rec.bytes,
+				LatencyMs: time.Since(start).Milliseconds(),
+				Geo:       geo(r, ip, locator),
+			}
+
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				writer.Record(ctx, entry)
+			}()
+		})
+	}
+}
+
+// geo resolves a request-log Geometry from the X-Geo header, falling back
+// to locator's IP geolocation (when one is wired in) if the header is absent.
+func geo(r *http.Request, ip string, locator IPLocator) *model.Geometry {
+	if g := geoFromHeader(r); g != nil {
+		return g
+	}
+	if locator == nil {
+		return nil
+	}
+	g, err := locator.Locate(r.Context(), ip)
+	if err != nil {
+		return nil
+	}
+	return g
+}
+
+func remoteIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		return strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// geoFromHeader parses "X-Geo: lng,lat" into a GeoJSON Point, when the
+// client supplies one. See IPLocator for the fallback used otherwise.
+func geoFromHeader(r *http.Request) *model.Geometry {
+	raw := r.Header.Get("X-Geo")
+	if raw == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) != 2 {
+		return nil
+	}
+	lng, errLng := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	lat, errLat := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if errLng != nil || errLat != nil {
+		return nil
+	}
+	return &model.Geometry{Type: "Point", Coordinates: []float64{lng, lat}}
+}
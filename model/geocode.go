@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// GeocodeResult is the outcome of either a forward or a reverse geocode.
+type GeocodeResult struct {
+	Lng      float64 `json:"lng" bson:"lng"`
+	Lat      float64 `json:"lat" bson:"lat"`
+	Address  string  `json:"address,omitempty" bson:"address,omitempty"`
+	City     string  `json:"city,omitempty" bson:"city,omitempty"`
+	Country  string  `json:"country,omitempty" bson:"country,omitempty"`
+	Postcode string  `json:"postcode,omitempty" bson:"postcode,omitempty"`
+}
+
+// GeocodeCacheEntry is a cached address<->coordinates lookup, kept in the
+// geocode_cache collection behind a TTL index on CreatedAt.
+type GeocodeCacheEntry struct {
+	Key       string        `bson:"key"`
+	Kind      string        `bson:"kind"` // "forward" or "reverse"
+	Result    GeocodeResult `bson:"result"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
@@ -0,0 +1,58 @@
+// Package model holds the data structures shared by the repository, service
+// and api layers, independent of how they're stored or served.
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Geometry supports the full GeoJSON geometry set, not just Point.
+// Coordinates is stored as interface{} because its shape differs per Type
+// ([]float64 for Point, [][]float64 for LineString, and so on) - validating
+// that shape is the service layer's job, not the struct's.
+type Geometry struct {
+	Type        string      `json:"type" bson:"type"`
+	Coordinates interface{} `json:"coordinates,omitempty" bson:"coordinates,omitempty"`
+	Geometries  []Geometry  `json:"geometries,omitempty" bson:"geometries,omitempty"`
+}
+
+// Properties berisi data non-spasial dari sebuah fitur
+type Properties struct {
+	Name        string `json:"name" bson:"name"`
+	Description string `json:"description" bson:"description"`
+	// Address fields are filled in by reverse/forward geocoding when the
+	// client only supplies one side (coordinates or address) - see
+	// service.GeocodeService.
+	Address  string `json:"address,omitempty" bson:"address,omitempty"`
+	City     string `json:"city,omitempty" bson:"city,omitempty"`
+	Country  string `json:"country,omitempty" bson:"country,omitempty"`
+	Postcode string `json:"postcode,omitempty" bson:"postcode,omitempty"`
+}
+
+// LocationFeature adalah representasi lengkap dari sebuah fitur GeoJSON
+type LocationFeature struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Type       string             `json:"type" bson:"type"`
+	Properties Properties         `json:"properties" bson:"properties"`
+	Geometry   Geometry           `json:"geometry" bson:"geometry"`
+	// OwnerID is the user that created this feature. Visibility is "public"
+	// (default, visible to everyone) or "private" (visible only to the
+	// owner and admins) - see service.LocationService.
+	OwnerID    primitive.ObjectID `json:"ownerId,omitempty" bson:"owner_id,omitempty"`
+	Visibility string             `json:"visibility,omitempty" bson:"visibility,omitempty"`
+}
+
+// FeatureCollection adalah wrapper untuk mengembalikan array dari fitur
+type FeatureCollection struct {
+	Type     string            `json:"type"`
+	Features []LocationFeature `json:"features"`
+}
+
+// GeoFilter describes a $geoWithin-style query in a backend-agnostic way, so
+// repository implementations other than Mongo (e.g. PostGIS) can translate
+// it into their own query language instead of taking a raw bson.M.
+type GeoFilter struct {
+	Kind    string        // "bbox" or "polygon"
+	BBox    [2][2]float64 // [[minLng, minLat], [maxLng, maxLat]]
+	Polygon [][][]float64
+}
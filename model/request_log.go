@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RequestLog is one document per HTTP request, written by middleware.RequestLogger.
+type RequestLog struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TraceID   string             `json:"traceId" bson:"trace_id"`
+	Timestamp time.Time          `json:"timestamp" bson:"timestamp"`
+	Method    string             `json:"method" bson:"method"`
+	Path      string             `json:"path" bson:"path"`
+	Query     string             `json:"query" bson:"query"`
+	RemoteIP  string             `json:"remoteIp" bson:"remote_ip"`
+	UserAgent string             `json:"userAgent" bson:"user_agent"`
+	Status    int                `json:"status" bson:"status"`
+	Bytes     int                `json:"bytes" bson:"bytes"`
+	LatencyMs int64              `json:"latencyMs" bson:"latency_ms"`
+	// Geo is a GeoJSON Point, present only when the client supplied one via
+	// the X-Geo header or an IPLocator given to middleware.RequestLogger
+	// resolved the client IP, so a 2dsphere index on this field can answer
+	// "requests from within this region" queries.
+	Geo *Geometry `json:"geo,omitempty" bson:"geo,omitempty"`
+}
+
+// LogQuery is the pagination / time-range filter accepted by GET /admin/logs.
+type LogQuery struct {
+	From  *time.Time
+	To    *time.Time
+	Page  int
+	Limit int
+}
+
+// RequestLogPage is one page of request logs plus pagination metadata.
+type RequestLogPage struct {
+	Logs  []RequestLog `json:"logs"`
+	Total int64        `json:"total"`
+	Page  int          `json:"page"`
+	Limit int          `json:"limit"`
+}
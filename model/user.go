@@ -0,0 +1,12 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// User adalah akun yang dipakai untuk autentikasi dan kepemilikan fitur.
+type User struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Email        string             `json:"email" bson:"email"`
+	PasswordHash string             `json:"-" bson:"password_hash"`
+	// Role is "user" or "admin"; admins bypass feature ownership checks.
+	Role string `json:"role" bson:"role"`
+}
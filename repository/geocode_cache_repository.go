@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GeocodeCacheRepository is the storage contract for cached geocode lookups.
+type GeocodeCacheRepository interface {
+	Get(ctx context.Context, key string) (model.GeocodeResult, bool, error)
+	Set(ctx context.Context, key, kind string, result model.GeocodeResult) error
+}
+
+// MongoGeocodeCacheRepository stores entries in the "geocode_cache"
+// collection, behind a TTL index on created_at.
+type MongoGeocodeCacheRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoGeocodeCacheRepository wraps an existing Mongo collection.
+func NewMongoGeocodeCacheRepository(collection *mongo.Collection) *MongoGeocodeCacheRepository {
+	return &MongoGeocodeCacheRepository{collection: collection}
+}
+
+func (repo *MongoGeocodeCacheRepository) Get(ctx context.Context, key string) (model.GeocodeResult, bool, error) {
+	var entry model.GeocodeCacheEntry
+	err := repo.collection.FindOne(ctx, bson.M{"key": key}).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return model.GeocodeResult{}, false, nil
+	}
+	if err != nil {
+		return model.GeocodeResult{}, false, err
+	}
+	return entry.Result, true, nil
+}
+
+func (repo *MongoGeocodeCacheRepository) Set(ctx context.Context, key, kind string, result model.GeocodeResult) error {
+	entry := model.GeocodeCacheEntry{Key: key, Kind: kind, Result: result, CreatedAt: time.Now()}
+	_, err := repo.collection.UpdateOne(ctx, bson.M{"key": key}, bson.M{"$set": entry}, options.Update().SetUpsert(true))
+	return err
+}
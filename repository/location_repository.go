@@ -0,0 +1,197 @@
+// Package repository contains the persistence layer for locations. The
+// LocationRepository interface is what the service layer depends on, so a
+// different backend (e.g. PostGIS) can be swapped in without touching
+// business logic.
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LocationRepository is the storage contract for LocationFeature documents.
+type LocationRepository interface {
+	Insert(ctx context.Context, feature model.LocationFeature) (primitive.ObjectID, error)
+	InsertMany(ctx context.Context, features []model.LocationFeature) ([]primitive.ObjectID, error)
+	FindAll(ctx context.Context) ([]model.LocationFeature, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (model.LocationFeature, bool, error)
+	// FindVisible returns public features plus ownerID's own private ones -
+	// used for non-admin callers of List.
+	FindVisible(ctx context.Context, ownerID primitive.ObjectID) ([]model.LocationFeature, error)
+	FindNear(ctx context.Context, lng, lat, maxMeters float64, minMeters *float64, limit int64, callerID primitive.ObjectID, isAdmin bool) ([]model.LocationFeature, error)
+	FindWithin(ctx context.Context, filter model.GeoFilter, limit int64, callerID primitive.ObjectID, isAdmin bool) ([]model.LocationFeature, error)
+	Update(ctx context.Context, id primitive.ObjectID, feature model.LocationFeature) (bool, error)
+	Delete(ctx context.Context, id primitive.ObjectID) (bool, error)
+}
+
+// MongoLocationRepository is the MongoDB-backed LocationRepository, keyed on
+// the 2dsphere index created on the "geometry" field.
+type MongoLocationRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLocationRepository wraps an existing Mongo collection.
+func NewMongoLocationRepository(collection *mongo.Collection) *MongoLocationRepository {
+	return &MongoLocationRepository{collection: collection}
+}
+
+func (repo *MongoLocationRepository) Insert(ctx context.Context, feature model.LocationFeature) (primitive.ObjectID, error) {
+	result, err := repo.collection.InsertOne(ctx, feature)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	oid, _ := result.InsertedID.(primitive.ObjectID)
+	return oid, nil
+}
+
+func (repo *MongoLocationRepository) InsertMany(ctx context.Context, features []model.LocationFeature) ([]primitive.ObjectID, error) {
+	docs := make([]interface{}, len(features))
+	for i, feature := range features {
+		docs[i] = feature
+	}
+
+	result, err := repo.collection.InsertMany(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(result.InsertedIDs))
+	for i, raw := range result.InsertedIDs {
+		ids[i], _ = raw.(primitive.ObjectID)
+	}
+	return ids, nil
+}
+
+func (repo *MongoLocationRepository) FindAll(ctx context.Context) ([]model.LocationFeature, error) {
+	cursor, err := repo.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	return decodeFeatures(ctx, cursor)
+}
+
+func (repo *MongoLocationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (model.LocationFeature, bool, error) {
+	var feature model.LocationFeature
+	err := repo.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&feature)
+	if err == mongo.ErrNoDocuments {
+		return model.LocationFeature{}, false, nil
+	}
+	if err != nil {
+		return model.LocationFeature{}, false, err
+	}
+	return feature, true, nil
+}
+
+func (repo *MongoLocationRepository) FindVisible(ctx context.Context, ownerID primitive.ObjectID) ([]model.LocationFeature, error) {
+	cursor, err := repo.collection.Find(ctx, visibilityFilter(ownerID))
+	if err != nil {
+		return nil, err
+	}
+	return decodeFeatures(ctx, cursor)
+}
+
+// visibilityFilter restricts a query to public features plus ownerID's own
+// private ones. Callers that already know they're admin should skip it.
+func visibilityFilter(ownerID primitive.ObjectID) bson.M {
+	return bson.M{"$or": []bson.M{
+		{"visibility": bson.M{"$ne": "private"}},
+		{"owner_id": ownerID},
+	}}
+}
+
+func (repo *MongoLocationRepository) FindNear(ctx context.Context, lng, lat, maxMeters float64, minMeters *float64, limit int64, callerID primitive.ObjectID, isAdmin bool) ([]model.LocationFeature, error) {
+	near := bson.M{
+		"$geometry": bson.M{
+			"type":        "Point",
+			"coordinates": []float64{lng, lat},
+		},
+		"$maxDistance": maxMeters,
+	}
+	if minMeters != nil {
+		near["$minDistance"] = *minMeters
+	}
+
+	query := bson.M{"geometry": bson.M{"$near": near}}
+	if !isAdmin {
+		query["$or"] = visibilityFilter(callerID)["$or"]
+	}
+
+	cursor, err := repo.collection.Find(ctx, query, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return decodeFeatures(ctx, cursor)
+}
+
+func (repo *MongoLocationRepository) FindWithin(ctx context.Context, filter model.GeoFilter, limit int64, callerID primitive.ObjectID, isAdmin bool) ([]model.LocationFeature, error) {
+	var geoWithin bson.M
+	switch filter.Kind {
+	case "bbox":
+		// $box is a legacy 2d-index operator that expects the field to hold a
+		// bare [lng, lat] pair - it doesn't match the GeoJSON sub-documents
+		// stored here under the 2dsphere index. Express the box as a closed
+		// Polygon ring instead, so it goes through the same $geometry path
+		// as the "polygon" case below.
+		min, max := filter.BBox[0], filter.BBox[1]
+		ring := [][]float64{
+			{min[0], min[1]},
+			{max[0], min[1]},
+			{max[0], max[1]},
+			{min[0], max[1]},
+			{min[0], min[1]},
+		}
+		geoWithin = bson.M{"$geometry": bson.M{"type": "Polygon", "coordinates": [][][]float64{ring}}}
+	case "polygon":
+		geoWithin = bson.M{"$geometry": bson.M{"type": "Polygon", "coordinates": filter.Polygon}}
+	default:
+		return nil, fmt.Errorf("unsupported geo filter kind: %q", filter.Kind)
+	}
+
+	query := bson.M{"geometry": bson.M{"$geoWithin": geoWithin}}
+	if !isAdmin {
+		query["$or"] = visibilityFilter(callerID)["$or"]
+	}
+
+	cursor, err := repo.collection.Find(ctx, query, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return decodeFeatures(ctx, cursor)
+}
+
+func (repo *MongoLocationRepository) Update(ctx context.Context, id primitive.ObjectID, feature model.LocationFeature) (bool, error) {
+	set := bson.M{"properties": feature.Properties}
+	if feature.Geometry.Type != "" {
+		set["geometry"] = feature.Geometry
+	}
+
+	result, err := repo.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (repo *MongoLocationRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := repo.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func decodeFeatures(ctx context.Context, cursor *mongo.Cursor) ([]model.LocationFeature, error) {
+	defer cursor.Close(ctx)
+
+	features := make([]model.LocationFeature, 0)
+	if err := cursor.All(ctx, &features); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
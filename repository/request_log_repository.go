@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RequestLogRepository is the storage contract for RequestLog documents.
+type RequestLogRepository interface {
+	Insert(ctx context.Context, log model.RequestLog) error
+	Find(ctx context.Context, query model.LogQuery) ([]model.RequestLog, int64, error)
+}
+
+// MongoRequestLogRepository stores request logs in the "request_logs" collection.
+type MongoRequestLogRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRequestLogRepository wraps an existing Mongo collection.
+func NewMongoRequestLogRepository(collection *mongo.Collection) *MongoRequestLogRepository {
+	return &MongoRequestLogRepository{collection: collection}
+}
+
+func (repo *MongoRequestLogRepository) Insert(ctx context.Context, log model.RequestLog) error {
+	_, err := repo.collection.InsertOne(ctx, log)
+	return err
+}
+
+func (repo *MongoRequestLogRepository) Find(ctx context.Context, query model.LogQuery) ([]model.RequestLog, int64, error) {
+	filter := bson.M{}
+	if query.From != nil || query.To != nil {
+		timestamp := bson.M{}
+		if query.From != nil {
+			timestamp["$gte"] = *query.From
+		}
+		if query.To != nil {
+			timestamp["$lte"] = *query.To
+		}
+		filter["timestamp"] = timestamp
+	}
+
+	total, err := repo.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((query.Page - 1) * query.Limit)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(int64(query.Limit))
+
+	cursor, err := repo.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	logs := make([]model.RequestLog, 0)
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrUserNotFound is returned by FindByEmail when no user matches.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrEmailTaken is returned by Insert when the email's unique index rejects
+// the write, which also catches the race between two concurrent
+// registrations for the same address.
+var ErrEmailTaken = errors.New("email already registered")
+
+// UserRepository is the storage contract for user accounts.
+type UserRepository interface {
+	Insert(ctx context.Context, user model.User) (primitive.ObjectID, error)
+	FindByEmail(ctx context.Context, email string) (model.User, error)
+}
+
+// MongoUserRepository stores accounts in the "users" collection.
+type MongoUserRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoUserRepository wraps an existing Mongo collection.
+func NewMongoUserRepository(collection *mongo.Collection) *MongoUserRepository {
+	return &MongoUserRepository{collection: collection}
+}
+
+func (repo *MongoUserRepository) Insert(ctx context.Context, user model.User) (primitive.ObjectID, error) {
+	result, err := repo.collection.InsertOne(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		return primitive.NilObjectID, ErrEmailTaken
+	}
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	oid, _ := result.InsertedID.(primitive.ObjectID)
+	return oid, nil
+}
+
+func (repo *MongoUserRepository) FindByEmail(ctx context.Context, email string) (model.User, error) {
+	var user model.User
+	err := repo.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		return model.User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+	return user, nil
+}
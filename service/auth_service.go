@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GIS-GITA/UTS-BE/auth"
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/GIS-GITA/UTS-BE/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tokenTTL is how long a login token stays valid.
+const tokenTTL = 24 * time.Hour
+
+// ErrInvalidCredentials is returned by Login when the email/password pair
+// doesn't match a stored account.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// ErrEmailTaken is returned by Register when the email is already registered.
+var ErrEmailTaken = errors.New("email already registered")
+
+// AuthService implements registration and login on top of a
+// repository.UserRepository, issuing JWTs signed with jwtSecret.
+type AuthService struct {
+	repo      repository.UserRepository
+	jwtSecret string
+}
+
+// NewAuthService wires an AuthService to the given repository and signing secret.
+func NewAuthService(repo repository.UserRepository, jwtSecret string) *AuthService {
+	return &AuthService{repo: repo, jwtSecret: jwtSecret}
+}
+
+// normalizeEmail lower-cases and trims an email so the same address can't
+// be registered twice under different casing.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Register hashes password and creates a new "user"-role account. The
+// FindByEmail check gives a fast, friendly rejection for the common case;
+// Insert's unique-index error is the source of truth, since two concurrent
+// registrations for the same address can both pass the check above.
+func (s *AuthService) Register(ctx context.Context, email, password string) (model.User, error) {
+	email = normalizeEmail(email)
+	if email == "" || password == "" {
+		return model.User{}, validationErrorf("email and password are required")
+	}
+
+	if _, err := s.repo.FindByEmail(ctx, email); err == nil {
+		return model.User{}, ErrEmailTaken
+	} else if !errors.Is(err, repository.ErrUserNotFound) {
+		return model.User{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return model.User{}, validationErrorf("%v", err)
+	}
+
+	user := model.User{Email: email, PasswordHash: string(hash), Role: "user"}
+	id, err := s.repo.Insert(ctx, user)
+	if errors.Is(err, repository.ErrEmailTaken) {
+		return model.User{}, ErrEmailTaken
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+	user.ID = id
+	return user, nil
+}
+
+// Login verifies credentials and returns a signed JWT valid for tokenTTL.
+func (s *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+	user, err := s.repo.FindByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return "", ErrInvalidCredentials
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	return auth.NewToken(s.jwtSecret, user.ID, user.Role, tokenTTL)
+}
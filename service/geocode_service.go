@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/GIS-GITA/UTS-BE/geocoder"
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/GIS-GITA/UTS-BE/repository"
+)
+
+// GeocodeService resolves addresses <-> coordinates through a pluggable
+// geocoder.Geocoder, caching both directions to stay within provider rate
+// limits.
+type GeocodeService struct {
+	provider geocoder.Geocoder
+	cache    repository.GeocodeCacheRepository
+}
+
+// NewGeocodeService wires a GeocodeService to a provider and its cache.
+func NewGeocodeService(provider geocoder.Geocoder, cache repository.GeocodeCacheRepository) *GeocodeService {
+	return &GeocodeService{provider: provider, cache: cache}
+}
+
+func roundCoord(v float64) float64 {
+	return math.Round(v*1e5) / 1e5
+}
+
+func forwardCacheKey(query string) string {
+	return "forward:" + query
+}
+
+func reverseCacheKey(lng, lat float64) string {
+	return fmt.Sprintf("reverse:%.5f,%.5f", roundCoord(lng), roundCoord(lat))
+}
+
+// Forward resolves a free-text query into coordinates.
+func (s *GeocodeService) Forward(ctx context.Context, query string) (model.GeocodeResult, error) {
+	if query == "" {
+		return model.GeocodeResult{}, validationErrorf("q must not be empty")
+	}
+
+	key := forwardCacheKey(query)
+	if cached, ok, err := s.cache.Get(ctx, key); err == nil && ok {
+		return cached, nil
+	}
+
+	result, err := s.provider.Forward(ctx, query)
+	if err != nil {
+		return model.GeocodeResult{}, err
+	}
+	_ = s.cache.Set(ctx, key, "forward", result)
+	return result, nil
+}
+
+// Reverse resolves coordinates into an address.
+func (s *GeocodeService) Reverse(ctx context.Context, lng, lat float64) (model.GeocodeResult, error) {
+	if err := validateLngLat(lng, lat); err != nil {
+		return model.GeocodeResult{}, &ValidationError{msg: err.Error()}
+	}
+
+	key := reverseCacheKey(lng, lat)
+	if cached, ok, err := s.cache.Get(ctx, key); err == nil && ok {
+		return cached, nil
+	}
+
+	result, err := s.provider.Reverse(ctx, lng, lat)
+	if err != nil {
+		return model.GeocodeResult{}, err
+	}
+	_ = s.cache.Set(ctx, key, "reverse", result)
+	return result, nil
+}
+
+// FillFromCoordinates reverse-geocodes a feature's Point geometry into its
+// address fields. Used when a feature has coordinates but no address.
+func (s *GeocodeService) FillFromCoordinates(ctx context.Context, feature *model.LocationFeature) error {
+	pos, err := toPosition(feature.Geometry.Coordinates)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.Reverse(ctx, pos[0], pos[1])
+	if err != nil {
+		return err
+	}
+
+	feature.Properties.Address = result.Address
+	feature.Properties.City = result.City
+	feature.Properties.Country = result.Country
+	feature.Properties.Postcode = result.Postcode
+	return nil
+}
+
+// FillCoordinates forward-geocodes a feature's address into a Point
+// geometry. Used when a feature has an address but no coordinates.
+func (s *GeocodeService) FillCoordinates(ctx context.Context, feature *model.LocationFeature) error {
+	result, err := s.Forward(ctx, feature.Properties.Address)
+	if err != nil {
+		return err
+	}
+
+	feature.Geometry = model.Geometry{Type: "Point", Coordinates: []float64{result.Lng, result.Lat}}
+	return nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+)
+
+// Geometry validation mostly leans on the fact that incoming request bodies
+// are decoded with encoding/json, which always turns nested arrays into
+// []interface{} and numbers into float64. But Coordinates is also set
+// directly by our own code (import parsers, reverse/forward geocoding)
+// using native []float64/[][]float64, so toPosition/toPositions accept
+// both shapes - see importexport.toPoint for the same reasoning.
+
+func toFloat(v interface{}) (float64, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return 0, fmt.Errorf("expected a number, got %T", v)
+	}
+	return f, nil
+}
+
+// toPosition validates a single [lng, lat] or [lng, lat, alt] position.
+func toPosition(v interface{}) ([]float64, error) {
+	if native, ok := v.([]float64); ok {
+		if len(native) < 2 || len(native) > 3 {
+			return nil, fmt.Errorf("position must have 2 or 3 coordinates, got %d", len(native))
+		}
+		pos := make([]float64, len(native))
+		copy(pos, native)
+		return pos, nil
+	}
+
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a position array, got %T", v)
+	}
+	if len(arr) < 2 || len(arr) > 3 {
+		return nil, fmt.Errorf("position must have 2 or 3 coordinates, got %d", len(arr))
+	}
+	pos := make([]float64, len(arr))
+	for i, e := range arr {
+		f, err := toFloat(e)
+		if err != nil {
+			return nil, err
+		}
+		pos[i] = f
+	}
+	return pos, nil
+}
+
+// toPositions validates an array of positions (used by LineString, MultiPoint).
+func toPositions(v interface{}) ([][]float64, error) {
+	if native, ok := v.([][]float64); ok {
+		out := make([][]float64, len(native))
+		for i, e := range native {
+			pos, err := toPosition(e)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = pos
+		}
+		return out, nil
+	}
+
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array of positions, got %T", v)
+	}
+	out := make([][]float64, len(arr))
+	for i, e := range arr {
+		pos, err := toPosition(e)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = pos
+	}
+	return out, nil
+}
+
+// toRing validates a closed linear ring (used by Polygon).
+func toRing(v interface{}) ([][]float64, error) {
+	ring, err := toPositions(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(ring) < 4 {
+		return nil, fmt.Errorf("polygon ring must have at least 4 positions, got %d", len(ring))
+	}
+	first, last := ring[0], ring[len(ring)-1]
+	if first[0] != last[0] || first[1] != last[1] {
+		return nil, fmt.Errorf("polygon ring must be closed (first and last position must match)")
+	}
+	return ring, nil
+}
+
+// toPolygon validates an array of rings (the first is the outer ring).
+func toPolygon(v interface{}) ([][][]float64, error) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array of rings, got %T", v)
+	}
+	if len(arr) == 0 {
+		return nil, fmt.Errorf("polygon must have at least one ring")
+	}
+	out := make([][][]float64, len(arr))
+	for i, e := range arr {
+		ring, err := toRing(e)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = ring
+	}
+	return out, nil
+}
+
+func validatePositions(positions [][]float64) error {
+	for _, p := range positions {
+		if err := validateLngLat(p[0], p[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLngLat rejects coordinates outside the valid GeoJSON range.
+func validateLngLat(lng, lat float64) error {
+	if lng < -180 || lng > 180 {
+		return fmt.Errorf("lng must be within [-180, 180], got %v", lng)
+	}
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("lat must be within [-90, 90], got %v", lat)
+	}
+	return nil
+}
+
+// validateGeometry rejects malformed or unsupported GeoJSON geometries.
+func validateGeometry(g model.Geometry) error {
+	switch g.Type {
+	case "Point":
+		pos, err := toPosition(g.Coordinates)
+		if err != nil {
+			return err
+		}
+		return validateLngLat(pos[0], pos[1])
+
+	case "MultiPoint":
+		positions, err := toPositions(g.Coordinates)
+		if err != nil {
+			return err
+		}
+		return validatePositions(positions)
+
+	case "LineString":
+		positions, err := toPositions(g.Coordinates)
+		if err != nil {
+			return err
+		}
+		if len(positions) < 2 {
+			return fmt.Errorf("LineString must have at least 2 positions, got %d", len(positions))
+		}
+		return validatePositions(positions)
+
+	case "MultiLineString":
+		arr, ok := g.Coordinates.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected an array of LineStrings, got %T", g.Coordinates)
+		}
+		for _, e := range arr {
+			positions, err := toPositions(e)
+			if err != nil {
+				return err
+			}
+			if len(positions) < 2 {
+				return fmt.Errorf("each LineString must have at least 2 positions, got %d", len(positions))
+			}
+			if err := validatePositions(positions); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "Polygon":
+		rings, err := toPolygon(g.Coordinates)
+		if err != nil {
+			return err
+		}
+		for _, ring := range rings {
+			if err := validatePositions(ring); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "MultiPolygon":
+		arr, ok := g.Coordinates.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected an array of Polygons, got %T", g.Coordinates)
+		}
+		for _, e := range arr {
+			rings, err := toPolygon(e)
+			if err != nil {
+				return err
+			}
+			for _, ring := range rings {
+				if err := validatePositions(ring); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+
+	case "GeometryCollection":
+		if len(g.Geometries) == 0 {
+			return fmt.Errorf("GeometryCollection must have at least one geometry")
+		}
+		for _, geom := range g.Geometries {
+			if err := validateGeometry(geom); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported geometry type: %q", g.Type)
+	}
+}
@@ -0,0 +1,284 @@
+// Package service holds the business rules and validation for locations,
+// on top of a repository.LocationRepository. HTTP concerns (status codes,
+// query-param parsing) stay in the api package.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/GIS-GITA/UTS-BE/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ValidationError marks a request as rejected for business-rule reasons
+// (bad geometry, out-of-range coordinates, ...) rather than a storage
+// failure, so the api layer can map it to a 400 instead of a 500.
+type ValidationError struct {
+	msg string
+}
+
+func (e *ValidationError) Error() string { return e.msg }
+
+func validationErrorf(format string, args ...interface{}) error {
+	return &ValidationError{msg: fmt.Sprintf(format, args...)}
+}
+
+// ErrNotFound is returned by Update/Delete when the id doesn't match any
+// stored feature.
+var ErrNotFound = errors.New("location not found")
+
+// ErrForbidden is returned by Update/Delete when the caller doesn't own the
+// feature and isn't an admin.
+var ErrForbidden = errors.New("not authorized to modify this feature")
+
+// geocodeEnricher fills in whichever side (address or coordinates) a
+// feature is missing. Optional: a nil geocoder disables enrichment.
+type geocodeEnricher interface {
+	FillFromCoordinates(ctx context.Context, feature *model.LocationFeature) error
+	FillCoordinates(ctx context.Context, feature *model.LocationFeature) error
+}
+
+// LocationService implements the business rules on top of a LocationRepository.
+type LocationService struct {
+	repo     repository.LocationRepository
+	geocoder geocodeEnricher
+}
+
+// NewLocationService wires a LocationService to the given repository. geocoder
+// may be nil to disable address/coordinate enrichment.
+func NewLocationService(repo repository.LocationRepository, geocoder geocodeEnricher) *LocationService {
+	return &LocationService{repo: repo, geocoder: geocoder}
+}
+
+func hasCoordinates(g model.Geometry) bool {
+	return g.Type != "" && g.Coordinates != nil
+}
+
+func hasAddress(p model.Properties) bool {
+	return p.Address != ""
+}
+
+// enrich fills in an address from coordinates, or coordinates from an
+// address, whichever side is missing. Geocoding failures are non-fatal -
+// the feature is still created/updated with whatever the client sent.
+func (s *LocationService) enrich(ctx context.Context, feature *model.LocationFeature) {
+	if s.geocoder == nil {
+		return
+	}
+	switch {
+	case hasCoordinates(feature.Geometry) && !hasAddress(feature.Properties):
+		_ = s.geocoder.FillFromCoordinates(ctx, feature)
+	case hasAddress(feature.Properties) && !hasCoordinates(feature.Geometry):
+		_ = s.geocoder.FillCoordinates(ctx, feature)
+	}
+}
+
+// validateVisibility defaults an empty Visibility to "public" and rejects
+// anything other than "public" or "private".
+func validateVisibility(feature *model.LocationFeature) error {
+	if feature.Visibility == "" {
+		feature.Visibility = "public"
+	}
+	if feature.Visibility != "public" && feature.Visibility != "private" {
+		return validationErrorf(`visibility must be "public" or "private"`)
+	}
+	return nil
+}
+
+// Create validates and inserts a single feature, owned by ownerID.
+func (s *LocationService) Create(ctx context.Context, feature model.LocationFeature, ownerID primitive.ObjectID) (model.LocationFeature, error) {
+	s.enrich(ctx, &feature)
+	feature.Type = "Feature"
+	feature.OwnerID = ownerID
+	if err := validateVisibility(&feature); err != nil {
+		return model.LocationFeature{}, err
+	}
+	if err := validateGeometry(feature.Geometry); err != nil {
+		return model.LocationFeature{}, &ValidationError{msg: err.Error()}
+	}
+
+	id, err := s.repo.Insert(ctx, feature)
+	if err != nil {
+		return model.LocationFeature{}, err
+	}
+	feature.ID = id
+	return feature, nil
+}
+
+// CreateBulk validates and inserts every feature of an incoming
+// FeatureCollection, all owned by ownerID.
+func (s *LocationService) CreateBulk(ctx context.Context, features []model.LocationFeature, ownerID primitive.ObjectID) ([]model.LocationFeature, error) {
+	if len(features) == 0 {
+		return nil, validationErrorf("FeatureCollection has no features")
+	}
+
+	for i := range features {
+		s.enrich(ctx, &features[i])
+		features[i].Type = "Feature"
+		features[i].OwnerID = ownerID
+		if err := validateVisibility(&features[i]); err != nil {
+			return nil, err
+		}
+		if err := validateGeometry(features[i].Geometry); err != nil {
+			return nil, validationErrorf("feature %d: %v", i, err)
+		}
+	}
+
+	ids, err := s.repo.InsertMany(ctx, features)
+	if err != nil {
+		return nil, err
+	}
+	for i := range features {
+		if i < len(ids) {
+			features[i].ID = ids[i]
+		}
+	}
+	return features, nil
+}
+
+// List returns every public feature plus, for a non-admin caller, the
+// caller's own private features. Admins see everything.
+func (s *LocationService) List(ctx context.Context, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error) {
+	var (
+		features []model.LocationFeature
+		err      error
+	)
+	if isAdmin {
+		features, err = s.repo.FindAll(ctx)
+	} else {
+		features, err = s.repo.FindVisible(ctx, callerID)
+	}
+	if err != nil {
+		return model.FeatureCollection{}, err
+	}
+	return model.FeatureCollection{Type: "FeatureCollection", Features: features}, nil
+}
+
+// Near returns features within maxMeters (and, optionally, outside
+// minMeters) of the given point, subject to the same public/private
+// visibility rules as List.
+func (s *LocationService) Near(ctx context.Context, lng, lat, maxMeters float64, minMeters *float64, limit int64, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error) {
+	if err := validateLngLat(lng, lat); err != nil {
+		return model.FeatureCollection{}, &ValidationError{msg: err.Error()}
+	}
+	if maxMeters <= 0 {
+		return model.FeatureCollection{}, validationErrorf("maxMeters must be positive")
+	}
+	if minMeters != nil && *minMeters < 0 {
+		return model.FeatureCollection{}, validationErrorf("minMeters must not be negative")
+	}
+
+	features, err := s.repo.FindNear(ctx, lng, lat, maxMeters, minMeters, limit, callerID, isAdmin)
+	if err != nil {
+		return model.FeatureCollection{}, err
+	}
+	return model.FeatureCollection{Type: "FeatureCollection", Features: features}, nil
+}
+
+// WithinBBox returns features inside the given bounding box, subject to the
+// same public/private visibility rules as List.
+func (s *LocationService) WithinBBox(ctx context.Context, minLng, minLat, maxLng, maxLat float64, limit int64, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error) {
+	if err := validateLngLat(minLng, minLat); err != nil {
+		return model.FeatureCollection{}, &ValidationError{msg: err.Error()}
+	}
+	if err := validateLngLat(maxLng, maxLat); err != nil {
+		return model.FeatureCollection{}, &ValidationError{msg: err.Error()}
+	}
+
+	filter := model.GeoFilter{Kind: "bbox", BBox: [2][2]float64{{minLng, minLat}, {maxLng, maxLat}}}
+	features, err := s.repo.FindWithin(ctx, filter, limit, callerID, isAdmin)
+	if err != nil {
+		return model.FeatureCollection{}, err
+	}
+	return model.FeatureCollection{Type: "FeatureCollection", Features: features}, nil
+}
+
+// WithinPolygon returns features inside the given GeoJSON Polygon, subject
+// to the same public/private visibility rules as List.
+func (s *LocationService) WithinPolygon(ctx context.Context, geom model.Geometry, limit int64, callerID primitive.ObjectID, isAdmin bool) (model.FeatureCollection, error) {
+	if geom.Type != "Polygon" {
+		return model.FeatureCollection{}, validationErrorf("body must be a GeoJSON Polygon")
+	}
+	if err := validateGeometry(geom); err != nil {
+		return model.FeatureCollection{}, &ValidationError{msg: err.Error()}
+	}
+
+	coordinates, err := toPolygon(geom.Coordinates)
+	if err != nil {
+		return model.FeatureCollection{}, &ValidationError{msg: err.Error()}
+	}
+
+	filter := model.GeoFilter{Kind: "polygon", Polygon: coordinates}
+	features, err := s.repo.FindWithin(ctx, filter, limit, callerID, isAdmin)
+	if err != nil {
+		return model.FeatureCollection{}, err
+	}
+	return model.FeatureCollection{Type: "FeatureCollection", Features: features}, nil
+}
+
+// Update validates and applies an update to an existing feature: its
+// properties are replaced as a whole, and its geometry only when the update
+// carries one. The caller must own the feature, unless isAdmin is set.
+func (s *LocationService) Update(ctx context.Context, id primitive.ObjectID, feature model.LocationFeature, callerID primitive.ObjectID, isAdmin bool) (model.LocationFeature, error) {
+	existing, ok, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return model.LocationFeature{}, err
+	}
+	if !ok {
+		return model.LocationFeature{}, ErrNotFound
+	}
+	if !isAdmin && existing.OwnerID != callerID {
+		return model.LocationFeature{}, ErrForbidden
+	}
+
+	s.enrich(ctx, &feature)
+
+	if feature.Geometry.Type != "" {
+		if err := validateGeometry(feature.Geometry); err != nil {
+			return model.LocationFeature{}, &ValidationError{msg: err.Error()}
+		}
+	}
+
+	matched, err := s.repo.Update(ctx, id, feature)
+	if err != nil {
+		return model.LocationFeature{}, err
+	}
+	if !matched {
+		return model.LocationFeature{}, ErrNotFound
+	}
+
+	// repo.Update only touches properties/geometry, so ownership and
+	// visibility are unchanged - reflect that in the response instead of
+	// the zero values an update request typically omits.
+	feature.ID = id
+	feature.OwnerID = existing.OwnerID
+	feature.Visibility = existing.Visibility
+	return feature, nil
+}
+
+// Delete removes a feature by id. The caller must own the feature, unless
+// isAdmin is set.
+func (s *LocationService) Delete(ctx context.Context, id primitive.ObjectID, callerID primitive.ObjectID, isAdmin bool) error {
+	existing, ok, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	if !isAdmin && existing.OwnerID != callerID {
+		return ErrForbidden
+	}
+
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeLocationRepository is an in-memory stand-in for repository.LocationRepository,
+// enough to exercise LocationService without a real Mongo connection.
+type fakeLocationRepository struct {
+	inserted []model.LocationFeature
+}
+
+func (f *fakeLocationRepository) Insert(ctx context.Context, feature model.LocationFeature) (primitive.ObjectID, error) {
+	id := primitive.NewObjectID()
+	feature.ID = id
+	f.inserted = append(f.inserted, feature)
+	return id, nil
+}
+
+func (f *fakeLocationRepository) InsertMany(ctx context.Context, features []model.LocationFeature) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, len(features))
+	for i, feature := range features {
+		id, _ := f.Insert(ctx, feature)
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func (f *fakeLocationRepository) FindAll(ctx context.Context) ([]model.LocationFeature, error) {
+	return f.inserted, nil
+}
+
+func (f *fakeLocationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (model.LocationFeature, bool, error) {
+	for _, feature := range f.inserted {
+		if feature.ID == id {
+			return feature, true, nil
+		}
+	}
+	return model.LocationFeature{}, false, nil
+}
+
+func (f *fakeLocationRepository) FindVisible(ctx context.Context, ownerID primitive.ObjectID) ([]model.LocationFeature, error) {
+	return f.inserted, nil
+}
+
+func (f *fakeLocationRepository) FindNear(ctx context.Context, lng, lat, maxMeters float64, minMeters *float64, limit int64, callerID primitive.ObjectID, isAdmin bool) ([]model.LocationFeature, error) {
+	return f.inserted, nil
+}
+
+func (f *fakeLocationRepository) FindWithin(ctx context.Context, filter model.GeoFilter, limit int64, callerID primitive.ObjectID, isAdmin bool) ([]model.LocationFeature, error) {
+	return f.inserted, nil
+}
+
+func (f *fakeLocationRepository) Update(ctx context.Context, id primitive.ObjectID, feature model.LocationFeature) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeLocationRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	return true, nil
+}
+
+func TestLocationServiceCreate(t *testing.T) {
+	repo := &fakeLocationRepository{}
+	svc := NewLocationService(repo, nil)
+	ownerID := primitive.NewObjectID()
+
+	feature := model.LocationFeature{
+		Properties: model.Properties{Name: "Monas"},
+		Geometry:   model.Geometry{Type: "Point", Coordinates: []float64{106.8272, -6.1754}},
+	}
+
+	created, err := svc.Create(context.Background(), feature, ownerID)
+	if err != nil {
+		t.Fatalf("Create returned unexpected error: %v", err)
+	}
+	if created.ID.IsZero() {
+		t.Fatal("Create did not assign an ID")
+	}
+	if created.OwnerID != ownerID {
+		t.Fatalf("OwnerID = %v, want %v", created.OwnerID, ownerID)
+	}
+	if created.Visibility != "public" {
+		t.Fatalf("Visibility = %q, want \"public\"", created.Visibility)
+	}
+	if len(repo.inserted) != 1 {
+		t.Fatalf("expected 1 feature inserted, got %d", len(repo.inserted))
+	}
+}
+
+func TestLocationServiceCreateRejectsInvalidGeometry(t *testing.T) {
+	repo := &fakeLocationRepository{}
+	svc := NewLocationService(repo, nil)
+
+	feature := model.LocationFeature{
+		Geometry: model.Geometry{Type: "Point", Coordinates: []float64{200, -6.1754}},
+	}
+
+	if _, err := svc.Create(context.Background(), feature, primitive.NewObjectID()); err == nil {
+		t.Fatal("expected Create to reject an out-of-range longitude, got nil error")
+	}
+	if len(repo.inserted) != 0 {
+		t.Fatalf("expected no features inserted, got %d", len(repo.inserted))
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/GIS-GITA/UTS-BE/model"
+	"github.com/GIS-GITA/UTS-BE/repository"
+)
+
+const (
+	defaultLogPage  = 1
+	defaultLogLimit = 50
+	maxLogLimit     = 500
+)
+
+// RequestLogService records request logs and serves them back paginated.
+type RequestLogService struct {
+	repo repository.RequestLogRepository
+}
+
+// NewRequestLogService wires a RequestLogService to the given repository.
+func NewRequestLogService(repo repository.RequestLogRepository) *RequestLogService {
+	return &RequestLogService{repo: repo}
+}
+
+// Record persists one request log entry. Satisfies middleware.RequestLogWriter.
+func (s *RequestLogService) Record(ctx context.Context, log model.RequestLog) error {
+	return s.repo.Insert(ctx, log)
+}
+
+// List returns a page of request logs, most recent first.
+func (s *RequestLogService) List(ctx context.Context, query model.LogQuery) (model.RequestLogPage, error) {
+	if query.Page <= 0 {
+		query.Page = defaultLogPage
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultLogLimit
+	}
+	if query.Limit > maxLogLimit {
+		query.Limit = maxLogLimit
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return model.RequestLogPage{}, validationErrorf("from must not be after to")
+	}
+
+	logs, total, err := s.repo.Find(ctx, query)
+	if err != nil {
+		return model.RequestLogPage{}, err
+	}
+
+	return model.RequestLogPage{Logs: logs, Total: total, Page: query.Page, Limit: query.Limit}, nil
+}